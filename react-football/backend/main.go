@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"sort"
@@ -18,24 +19,85 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
+
+	"github.com/ha2mza/react-kick-football/backend/queue"
 )
 
 // Configuration
 var (
-	port           = getEnv("PORT", "8080")
-	redisURL       = getEnv("REDIS_URL", "localhost:6379")
-	redisPassword  = getEnv("REDIS_PASSWORD", "")
-	redisDB        = getEnvInt("REDIS_DB", 0)
-	rateLimitTime  = 100 * time.Millisecond // Minimum time between clicks per IP
-	saveInterval   = 10 * time.Minute       // Save to Redis every 10 minutes
+	port              = getEnv("PORT", "8080")
+	connStr           = getEnv("CONN_STR", "")
+	redisURL          = getEnv("REDIS_URL", "localhost:6379")
+	redisPassword     = getEnv("REDIS_PASSWORD", "")
+	redisDB           = getEnvInt("REDIS_DB", 0)
+	redisMode         = getEnv("REDIS_MODE", "single") // single | cluster
+	redisAddrs        = getEnv("REDIS_ADDRS", "")      // cluster mode: "addrs=host1:6379,host2:6379"
+	queueType         = getEnv("QUEUE_TYPE", "memory") // memory | redis | redis-streams
+	queueWorkers      = getEnvInt("QUEUE_WORKERS", 4)
+	rateLimitTime     = 100 * time.Millisecond // Minimum time between clicks per IP
+	cacheRefreshEvery = 2 * time.Second        // How often the read-through cache is refreshed from Redis
 )
 
 // Redis keys
 const (
-	redisKeyClicks    = "football:clicks"
-	redisKeyStartTime = "football:start_time"
+	redisKeyClicks      = "football:clicks"
+	redisKeyStartTime   = "football:start_time"
+	redisChanEvents     = "football:events"        // pub/sub channel for cross-node click deltas
+	redisKeyScoreGlobal = "football:scores:global" // ZSET: playerID -> best score, worldwide
+	redisKeyPlayers     = "football:players"       // HASH: playerID -> nickname
+)
+
+func redisKeyScoreCountry(country string) string {
+	return "football:scores:" + country
+}
+
+// redisKeyMinuteBucket holds per-country click counts for a single minute,
+// keyed by unix-minute (time.Now().Unix() / 60). It expires after
+// minuteBucketTTL so getLeaderboard's rolling-window sums never grow
+// unbounded.
+func redisKeyMinuteBucket(unixMinute int64) string {
+	return fmt.Sprintf("football:clicks:minute:%d", unixMinute)
+}
+
+// redisKeySnapshot holds the cumulative per-country click totals recorded by
+// takeDailySnapshot on that day: at local midnight, and again on each boot,
+// with the latest write for a field winning.
+func redisKeySnapshot(day time.Time) string {
+	return "football:snapshot:" + day.Format("20060102")
+}
+
+const (
+	// maxPlausibleScorePerSecond bounds how fast a legitimate score can
+	// climb; anything faster than this relative to the submitted
+	// startTime is rejected as implausible.
+	maxPlausibleScorePerSecond = 15.0
+	// maxSessionDuration bounds how long ago startTime may claim the
+	// session began. It stops the obvious bypass of maxPlausibleScorePerSecond
+	// (send an ancient startTime so elapsed, and the allowance, balloon) without
+	// requiring a server-side session store.
+	maxSessionDuration   = 10 * time.Minute
+	defaultScoreTopLimit = 100
+	maxScoreTopLimit     = 500
+
+	minuteBucketTTL  = 2 * time.Hour
+	snapshotInterval = 24 * time.Hour
+	maxHistoryDays   = 90
 )
 
+// rateLimitScript atomically checks and sets a per-IP rate limit key in one
+// round trip, so concurrent clicks from the same IP can't race past the
+// check-then-set window that a Go-side mutex used to serialize.
+const rateLimitScript = `
+local key = KEYS[1]
+local ttl = ARGV[1]
+if redis.call("SET", key, 1, "PX", ttl, "NX") then
+	return 1
+end
+return 0
+`
+
+var rateLimitLua = redis.NewScript(rateLimitScript)
+
 // Data structures
 type ClickPayload struct {
 	Country string `json:"country"`
@@ -43,25 +105,46 @@ type ClickPayload struct {
 }
 
 type ScorePayload struct {
-	Country string `json:"country"`
-	Score   int    `json:"score"`
+	Country   string `json:"country"`
+	Score     int    `json:"score"`
+	PlayerID  string `json:"playerId"`
+	Nickname  string `json:"nickname"`
+	StartTime int64  `json:"startTime"` // unix seconds the session began; used for the anti-cheat check
+}
+
+type ScoreEntry struct {
+	PlayerID string `json:"playerId"`
+	Nickname string `json:"nickname"`
+	Score    int64  `json:"score"`
 }
 
 type CountryStats struct {
 	Country string  `json:"country"`
 	Clicks  int64   `json:"clicks"`
-	KPS     float64 `json:"kps,omitempty"`
+	KPS     float64 `json:"kps,omitempty"`   // lifetime average, flattens over days
+	KPS1m   float64 `json:"kps1m,omitempty"` // current minute bucket, over the seconds it has covered so far
+	KPS5m   float64 `json:"kps5m,omitempty"` // last four full minute buckets plus the current one
 }
 
 type LeaderboardResponse []CountryStats
 
-// In-memory storage with Redis persistence
+// DaySnapshot is one day's per-country click totals, as recorded by
+// takeDailySnapshot.
+type DaySnapshot struct {
+	Date      string           `json:"date"`
+	Countries map[string]int64 `json:"countries"`
+}
+
+// Storage is a read-through cache over the Redis click counters. Redis is the
+// source of truth for every click (via HINCRBY); countryClicks here only
+// backs handleLeaderboard reads and is refreshed asynchronously by
+// refreshCache, so the hot click path never takes storage.mu.
 type Storage struct {
 	mu            sync.RWMutex
 	countryClicks map[string]int64
-	lastClickIP   map[string]time.Time // IP -> last click time
+	lastClickIP   map[string]time.Time // IP -> last click time, fallback mode only
 	startTime     time.Time
-	dirty         bool // Flag to track if data changed since last save
+	pendingClicks map[string]int64 // fallback mode only: increments not yet reconciled to Redis
 }
 
 var (
@@ -69,10 +152,15 @@ var (
 		countryClicks: make(map[string]int64),
 		lastClickIP:   make(map[string]time.Time),
 		startTime:     time.Now(),
-		dirty:         false,
+		pendingClicks: make(map[string]int64),
 	}
-	redisClient *redis.Client
-	ctx         = context.Background()
+	// redisClient is a UniversalClient so cluster mode (redis.ClusterClient)
+	// and single-node mode (redis.Client) are interchangeable everywhere
+	// else in the service.
+	redisClient    redis.UniversalClient
+	clickQueue     queue.Queue
+	leaderboardHub = newSSEHub()
+	ctx            = context.Background()
 )
 
 func getEnv(key, defaultValue string) string {
@@ -91,13 +179,75 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// parseConnStr parses a "redis://[:password@]host:port/db" style connection
+// string, falling back to the discrete REDIS_* env vars for anything it
+// can't read. This lets a single CONN_STR configure the one Redis client
+// that the cache, rate limiter, and queue all share.
+func parseConnStr(raw string) (addr, password string, db int) {
+	addr, password, db = redisURL, redisPassword, redisDB
+	if raw == "" {
+		return
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		log.Printf("⚠️  Invalid CONN_STR, falling back to REDIS_* env vars: %v", err)
+		return
+	}
+
+	if u.Host != "" {
+		addr = u.Host
+	}
+	if pw, ok := u.User.Password(); ok {
+		password = pw
+	}
+	if path := strings.TrimPrefix(u.Path, "/"); path != "" {
+		if parsed, err := strconv.Atoi(path); err == nil {
+			db = parsed
+		}
+	}
+	return
+}
+
+// clusterAddrs parses the cluster-mode connection string. It accepts either
+// "addrs=host1:6379,host2:6379" (REDIS_ADDRS or CONN_STR) or a bare
+// comma-separated host list.
+func clusterAddrs() []string {
+	raw := connStr
+	if raw == "" {
+		raw = redisAddrs
+	}
+	raw = strings.TrimPrefix(raw, "addrs=")
+	if raw == "" {
+		return []string{redisURL}
+	}
+
+	parts := strings.Split(raw, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
 // Initialize Redis connection
 func initRedis() error {
-	redisClient = redis.NewClient(&redis.Options{
-		Addr:     redisURL,
-		Password: redisPassword,
-		DB:       redisDB,
-	})
+	var addrDesc string
+	if redisMode == "cluster" {
+		addrs := clusterAddrs()
+		redisClient = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    addrs,
+			Password: redisPassword,
+		})
+		addrDesc = strings.Join(addrs, ",")
+	} else {
+		addr, password, db := parseConnStr(connStr)
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: password,
+			DB:       db,
+		})
+		addrDesc = addr
+	}
 
 	// Test connection
 	_, err := redisClient.Ping(ctx).Result()
@@ -105,7 +255,7 @@ func initRedis() error {
 		return fmt.Errorf("failed to connect to Redis: %v", err)
 	}
 
-	log.Printf("✅ Connected to Redis at %s", redisURL)
+	log.Printf("✅ Connected to Redis (%s mode) at %s", redisMode, addrDesc)
 	return nil
 }
 
@@ -154,90 +304,374 @@ func loadFromRedis() error {
 	return nil
 }
 
-// Save data to Redis
-func saveToRedis() error {
-	storage.mu.RLock()
-	defer storage.mu.RUnlock()
+// reconcilePending flushes clicks that were recorded locally while a HINCRBY
+// failed back into the Redis hash, via HINCRBY rather than a blind HSet, so
+// a reconcile can never clobber a concurrent write from another replica.
+// Countries that still fail stay in pendingClicks and are retried on the
+// next call.
+func reconcilePending() {
+	storage.mu.Lock()
+	pending := storage.pendingClicks
+	storage.pendingClicks = make(map[string]int64, len(pending))
+	storage.mu.Unlock()
 
-	if !storage.dirty && len(storage.countryClicks) == 0 {
-		return nil // Nothing to save
+	for country, clicks := range pending {
+		if clicks == 0 {
+			continue
+		}
+		if err := redisClient.HIncrBy(ctx, redisKeyClicks, country, clicks).Err(); err != nil {
+			log.Printf("⚠️  Failed to reconcile %d pending clicks for %s: %v", clicks, country, err)
+			storage.mu.Lock()
+			storage.pendingClicks[country] += clicks
+			storage.mu.Unlock()
+		}
 	}
+}
 
-	// Save country clicks as hash
-	if len(storage.countryClicks) > 0 {
-		clicksMap := make(map[string]interface{})
-		for country, clicks := range storage.countryClicks {
-			clicksMap[country] = clicks
+// Rate limiting check. When Redis is available this runs a Lua script that
+// does the check-and-set atomically server-side, so a click flood from one
+// IP can't race past a Go-side mutex. Falls back to an in-memory map when
+// Redis is unreachable.
+func checkRateLimit(ip string) bool {
+	if redisClient != nil {
+		allowed, err := rateLimitLua.Run(ctx, redisClient, []string{"ip:" + ip}, rateLimitTime.Milliseconds()).Int()
+		if err == nil {
+			return allowed == 1
 		}
-		
-		err := redisClient.HSet(ctx, redisKeyClicks, clicksMap).Err()
-		if err != nil {
-			return fmt.Errorf("failed to save clicks to Redis: %v", err)
+		log.Printf("⚠️  Rate limit script failed, falling back to memory: %v", err)
+	}
+	return checkRateLimitMemory(ip)
+}
+
+func checkRateLimitMemory(ip string) bool {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	lastClick, exists := storage.lastClickIP[ip]
+	now := time.Now()
+
+	if exists && now.Sub(lastClick) < rateLimitTime {
+		return false
+	}
+
+	storage.lastClickIP[ip] = now
+	return true
+}
+
+// Increment country clicks. The normal path writes straight through to the
+// Redis hash with HINCRBY so concurrent requests never contend on
+// storage.mu; only the Redis-down fallback touches the in-memory map.
+func incrementClicks(country string, clicks int64) {
+	if redisClient != nil {
+		if err := redisClient.HIncrBy(ctx, redisKeyClicks, country, clicks).Err(); err == nil {
+			recordMinuteBucket(country, clicks)
+			return
+		} else {
+			log.Printf("⚠️  HINCRBY failed, falling back to memory: %v", err)
 		}
 	}
+	incrementClicksMemory(country, clicks)
+}
 
-	storage.dirty = false
-	
-	// Calculate total for logging
-	var totalClicks int64
-	for _, clicks := range storage.countryClicks {
-		totalClicks += clicks
+// recordMinuteBucket adds to the current minute's per-country counter so
+// getLeaderboard can report true rolling-window KPS instead of a lifetime
+// average that flattens out over days.
+func recordMinuteBucket(country string, clicks int64) {
+	bucketKey := redisKeyMinuteBucket(time.Now().Unix() / 60)
+	pipe := redisClient.TxPipeline()
+	pipe.HIncrBy(ctx, bucketKey, country, clicks)
+	pipe.Expire(ctx, bucketKey, minuteBucketTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("⚠️  Failed to record minute bucket: %v", err)
+	}
+}
+
+// sumRecentBuckets sums the per-country minute buckets for the last
+// `minutes` minutes (including the current, partial one).
+func sumRecentBuckets(minutes int) (map[string]int64, error) {
+	nowMinute := time.Now().Unix() / 60
+	totals := make(map[string]int64)
+
+	for i := 0; i < minutes; i++ {
+		data, err := redisClient.HGetAll(ctx, redisKeyMinuteBucket(nowMinute-int64(i))).Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		for country, v := range data {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err == nil {
+				totals[country] += n
+			}
+		}
+	}
+	return totals, nil
+}
+
+// applyRollingKPS fills in KPS1m/KPS5m for every entry, including the
+// prepended Worldwide row, by summing recent minute buckets.
+func applyRollingKPS(entries LeaderboardResponse) {
+	counts1m, err := sumRecentBuckets(1)
+	if err != nil {
+		log.Printf("⚠️  Failed to sum 1m buckets: %v", err)
+		return
+	}
+	counts5m, err := sumRecentBuckets(5)
+	if err != nil {
+		log.Printf("⚠️  Failed to sum 5m buckets: %v", err)
+		return
+	}
+
+	// The current minute's bucket is still filling, so divide by the seconds
+	// the summed buckets actually cover rather than the nominal window.
+	partial := float64(time.Now().Unix()%60 + 1)
+	span1m, span5m := partial, 4*60.0+partial
+
+	for i := range entries {
+		if entries[i].Country == "Worldwide" {
+			var total1m, total5m int64
+			for _, n := range counts1m {
+				total1m += n
+			}
+			for _, n := range counts5m {
+				total5m += n
+			}
+			entries[i].KPS1m = float64(total1m) / span1m
+			entries[i].KPS5m = float64(total5m) / span5m
+			continue
+		}
+		entries[i].KPS1m = float64(counts1m[entries[i].Country]) / span1m
+		entries[i].KPS5m = float64(counts5m[entries[i].Country]) / span5m
+	}
+}
+
+// takeDailySnapshot writes the current per-country totals to a dated hash so
+// /api/leaderboard/history can report historical trends without an external
+// time-series database.
+func takeDailySnapshot() error {
+	storage.mu.RLock()
+	snap := make(map[string]interface{}, len(storage.countryClicks))
+	for country, clicks := range storage.countryClicks {
+		snap[country] = clicks
+	}
+	storage.mu.RUnlock()
+
+	if len(snap) == 0 {
+		return nil
 	}
-	log.Printf("💾 Saved to Redis: %d countries, %d total clicks", len(storage.countryClicks), totalClicks)
 
+	key := redisKeySnapshot(time.Now())
+	if err := redisClient.HSet(ctx, key, snap).Err(); err != nil {
+		return fmt.Errorf("failed to write daily snapshot: %v", err)
+	}
+	log.Printf("🗓️  Wrote daily snapshot %s (%d countries)", key, len(snap))
 	return nil
 }
 
-// Periodic save goroutine
-func startPeriodicSave(stopChan <-chan struct{}) {
-	ticker := time.NewTicker(saveInterval)
-	defer ticker.Stop()
+// startDailySnapshot periodically writes a daily snapshot until stopChan is
+// closed.
+func startDailySnapshot(stopChan <-chan struct{}) {
+	// Snapshot immediately on boot: in deployments that restart more than
+	// once a day (the k8s case this series targets), a pure 24h ticker
+	// would never fire and /api/leaderboard/history would stay empty.
+	if err := takeDailySnapshot(); err != nil {
+		log.Printf("❌ %v", err)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	timer := time.NewTimer(today.AddDate(0, 0, 1).Sub(now))
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
-			if err := saveToRedis(); err != nil {
-				log.Printf("❌ Error saving to Redis: %v", err)
+		case <-timer.C:
+			if err := takeDailySnapshot(); err != nil {
+				log.Printf("❌ %v", err)
 			}
+			timer.Reset(snapshotInterval) // subsequent ticks stay aligned to midnight
 		case <-stopChan:
-			log.Println("🛑 Stopping periodic save...")
-			// Final save before shutdown
-			if err := saveToRedis(); err != nil {
-				log.Printf("❌ Error in final save to Redis: %v", err)
-			}
 			return
 		}
 	}
 }
 
-// Rate limiting check
-func checkRateLimit(ip string) bool {
+func incrementClicksMemory(country string, clicks int64) {
 	storage.mu.Lock()
 	defer storage.mu.Unlock()
+	storage.countryClicks[country] += clicks
+	storage.pendingClicks[country] += clicks
+}
 
-	lastClick, exists := storage.lastClickIP[ip]
-	now := time.Now()
+// refreshCache pulls the current click counters out of Redis and replaces
+// the local read-through cache. handleLeaderboard always reads the cache, so
+// leaderboard requests never block on a Redis round trip either.
+//
+// It reconciles pendingClicks first so a HINCRBY that failed gets another
+// chance to land in Redis, and folds any clicks that are still stuck in
+// pendingClicks back into the fresh snapshot — otherwise the plain replace
+// below would erase them from the local cache with no trace anywhere.
+func refreshCache() error {
+	reconcilePending()
 
-	if exists && now.Sub(lastClick) < rateLimitTime {
-		return false
+	clicksData, err := redisClient.HGetAll(ctx, redisKeyClicks).Result()
+	if err != nil {
+		return fmt.Errorf("failed to refresh cache from Redis: %v", err)
 	}
 
-	storage.lastClickIP[ip] = now
-	return true
-}
+	fresh := make(map[string]int64, len(clicksData))
+	for country, clicksStr := range clicksData {
+		clicks, err := strconv.ParseInt(clicksStr, 10, 64)
+		if err == nil {
+			fresh[country] = clicks
+		}
+	}
 
-// Increment country clicks
-func incrementClicks(country string, clicks int64) {
 	storage.mu.Lock()
-	defer storage.mu.Unlock()
-	storage.countryClicks[country] += clicks
-	storage.dirty = true // Mark data as changed
+	for country, clicks := range storage.pendingClicks {
+		fresh[country] += clicks
+	}
+	storage.countryClicks = fresh
+	storage.mu.Unlock()
+	return nil
+}
+
+// startCacheRefresh periodically refreshes the read-through cache from
+// Redis. It runs until stopChan is closed.
+func startCacheRefresh(stopChan <-chan struct{}) {
+	ticker := time.NewTicker(cacheRefreshEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := refreshCache(); err != nil {
+				log.Printf("⚠️  %v", err)
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+// sseHub fans delta payloads out to every connected
+// /api/leaderboard/stream client.
+type sseHub struct {
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+}
+
+func newSSEHub() *sseHub {
+	return &sseHub{clients: make(map[chan []byte]struct{})}
+}
+
+func (h *sseHub) subscribe() chan []byte {
+	ch := make(chan []byte, 16)
+	h.mu.Lock()
+	h.clients[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *sseHub) unsubscribe(ch chan []byte) {
+	h.mu.Lock()
+	delete(h.clients, ch)
+	h.mu.Unlock()
+	close(ch)
+}
+
+func (h *sseHub) broadcast(payload []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.clients {
+		select {
+		case ch <- payload:
+		default: // slow client, drop rather than block the publisher
+		}
+	}
+}
+
+// publishClickEvent announces a click delta to every node in the cluster so
+// each instance's read-through cache reflects peers' writes between
+// refreshCache polls, and so /api/leaderboard/stream can push it to browsers
+// immediately.
+func publishClickEvent(evt queue.Event) {
+	if redisClient == nil {
+		return
+	}
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return
+	}
+	if err := redisClient.Publish(ctx, redisChanEvents, payload).Err(); err != nil {
+		log.Printf("⚠️  Failed to publish click event: %v", err)
+	}
+}
+
+// startClusterSync subscribes to football:events and applies peers' click
+// deltas to the local cache immediately, then forwards the delta to any
+// connected SSE clients. It runs until stopChan is closed.
+func startClusterSync(stopChan <-chan struct{}) {
+	sub := redisClient.Subscribe(ctx, redisChanEvents)
+	defer sub.Close()
+	msgs := sub.Channel()
+
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+
+			var evt queue.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &evt); err != nil {
+				log.Printf("⚠️  Dropping malformed cluster event: %v", err)
+				continue
+			}
+
+			storage.mu.Lock()
+			storage.countryClicks[evt.Country] += evt.Clicks
+			updated := storage.countryClicks[evt.Country]
+			storage.mu.Unlock()
+
+			stats, err := json.Marshal(CountryStats{Country: evt.Country, Clicks: updated})
+			if err == nil {
+				leaderboardHub.broadcast(stats)
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+// Handler: stream leaderboard deltas as Server-Sent Events
+func handleLeaderboardStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := leaderboardHub.subscribe()
+	defer leaderboardHub.unsubscribe(ch)
+
+	for {
+		select {
+		case payload := <-ch:
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
 }
 
 // Get leaderboard
 func getLeaderboard() LeaderboardResponse {
 	storage.mu.RLock()
-	defer storage.mu.RUnlock()
 
 	var totalClicks int64
 	var result LeaderboardResponse
@@ -249,6 +683,9 @@ func getLeaderboard() LeaderboardResponse {
 			Clicks:  clicks,
 		})
 	}
+	startTime := storage.startTime
+
+	storage.mu.RUnlock()
 
 	// Sort by clicks descending
 	sort.Slice(result, func(i, j int) bool {
@@ -256,7 +693,7 @@ func getLeaderboard() LeaderboardResponse {
 	})
 
 	// Calculate KPS (kicks per second) for worldwide
-	elapsed := time.Since(storage.startTime).Seconds()
+	elapsed := time.Since(startTime).Seconds()
 	kps := 0.0
 	if elapsed > 0 {
 		kps = float64(totalClicks) / elapsed
@@ -269,7 +706,13 @@ func getLeaderboard() LeaderboardResponse {
 		KPS:     kps,
 	}
 
-	return append(LeaderboardResponse{worldwide}, result...)
+	leaderboard := append(LeaderboardResponse{worldwide}, result...)
+
+	if redisClient != nil {
+		applyRollingKPS(leaderboard)
+	}
+
+	return leaderboard
 }
 
 // Get client IP
@@ -318,12 +761,64 @@ func handleClick(w http.ResponseWriter, r *http.Request) {
 		payload.Clicks = 10 // Max clicks per request
 	}
 
-	// Increment clicks
-	incrementClicks(payload.Country, int64(payload.Clicks))
+	// Enqueue the click and return immediately; a worker pool applies the
+	// HINCRBY, so HTTP latency is decoupled from Redis latency.
+	evt := queue.Event{
+		Country: payload.Country,
+		Clicks:  int64(payload.Clicks),
+		IP:      clientIP,
+		TS:      time.Now().Unix(),
+	}
+	if err := clickQueue.Enqueue(r.Context(), evt); err != nil {
+		http.Error(w, "Failed to queue click", http.StatusServiceUnavailable)
+		return
+	}
 
 	// Response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	json.NewEncoder(w).Encode(map[string]string{"status": "queued"})
+}
+
+// plausibleScore rejects scores that couldn't have been earned legitimately
+// in the time elapsed since the session's startTime. StartTime was
+// introduced alongside this check, so there's no older client whose
+// payloads need to be grandfathered in — a missing or zero StartTime is
+// rejected outright rather than treated as a free pass.
+//
+// startTime is client-supplied, so elapsed alone can't be trusted: a
+// cheater can claim an ancient startTime to inflate elapsed*maxPlausibleScorePerSecond
+// past any score. maxSessionDuration caps how far in the past startTime may
+// plausibly be, which bounds the allowance regardless of what the client
+// claims.
+func plausibleScore(payload ScorePayload) bool {
+	if payload.StartTime <= 0 {
+		return false
+	}
+	elapsed := float64(time.Now().Unix() - payload.StartTime)
+	if elapsed <= 0 || elapsed > maxSessionDuration.Seconds() {
+		return false
+	}
+	return float64(payload.Score) <= elapsed*maxPlausibleScorePerSecond
+}
+
+// recordScore persists the score to the player's best-of sorted sets (global
+// and per-country) and refreshes their display name. Only the best score per
+// player is kept, via Redis' ZADD GT.
+//
+// The three keys involved (global ZSET, per-country ZSET, players HASH)
+// don't share a hash slot, so this uses a plain (non-transactional)
+// pipeline: in cluster mode a TxPipeline's MULTI/EXEC would fail every call
+// with CROSSSLOT. None of these writes depend on the others succeeding
+// atomically, so the pipelining is purely for round-trip batching.
+func recordScore(payload ScorePayload) error {
+	member := redis.Z{Score: float64(payload.Score), Member: payload.PlayerID}
+
+	pipe := redisClient.Pipeline()
+	pipe.ZAddArgs(ctx, redisKeyScoreGlobal, redis.ZAddArgs{GT: true, Members: []redis.Z{member}})
+	pipe.ZAddArgs(ctx, redisKeyScoreCountry(payload.Country), redis.ZAddArgs{GT: true, Members: []redis.Z{member}})
+	pipe.HSet(ctx, redisKeyPlayers, payload.PlayerID, payload.Nickname)
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
 // Handler: Submit score
@@ -335,9 +830,30 @@ func handleScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if payload.Country == "" {
+		payload.Country = "Unknown"
+	}
+	if payload.PlayerID == "" {
+		payload.PlayerID = "anon:" + getClientIP(r)
+	}
+	if payload.Nickname == "" {
+		payload.Nickname = "Anonymous"
+	}
+
+	if !plausibleScore(payload) {
+		http.Error(w, "Implausible score rejected", http.StatusBadRequest)
+		return
+	}
+
 	// Log high scores (could store in database)
 	if payload.Score > 100 {
-		log.Printf("High score: %d from %s", payload.Score, payload.Country)
+		log.Printf("High score: %d from %s (%s)", payload.Score, payload.Country, payload.PlayerID)
+	}
+
+	if redisClient != nil {
+		if err := recordScore(payload); err != nil {
+			log.Printf("⚠️  Failed to record score: %v", err)
+		}
 	}
 
 	// Response
@@ -345,6 +861,77 @@ func handleScore(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
 
+// Handler: Top scores, worldwide or for a single country
+func handleScoreTop(w http.ResponseWriter, r *http.Request) {
+	if redisClient == nil {
+		http.Error(w, "Redis unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	country := r.URL.Query().Get("country")
+	limit := defaultScoreTopLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	if limit > maxScoreTopLimit {
+		limit = maxScoreTopLimit
+	}
+
+	key := redisKeyScoreGlobal
+	if country != "" {
+		key = redisKeyScoreCountry(country)
+	}
+
+	entries, err := redisClient.ZRevRangeWithScores(ctx, key, 0, int64(limit-1)).Result()
+	if err != nil {
+		http.Error(w, "Failed to load scores", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]ScoreEntry, 0, len(entries))
+	for _, e := range entries {
+		playerID, _ := e.Member.(string)
+		nickname, _ := redisClient.HGet(ctx, redisKeyPlayers, playerID).Result()
+		result = append(result, ScoreEntry{PlayerID: playerID, Nickname: nickname, Score: int64(e.Score)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// Handler: A single player's worldwide rank
+func handleScoreRank(w http.ResponseWriter, r *http.Request) {
+	if redisClient == nil {
+		http.Error(w, "Redis unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	player := r.URL.Query().Get("player")
+	if player == "" {
+		http.Error(w, "player is required", http.StatusBadRequest)
+		return
+	}
+
+	rank, err := redisClient.ZRevRank(ctx, redisKeyScoreGlobal, player).Result()
+	if err == redis.Nil {
+		http.Error(w, "Player not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "Failed to load rank", http.StatusInternalServerError)
+		return
+	}
+	score, _ := redisClient.ZScore(ctx, redisKeyScoreGlobal, player).Result()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"player": player,
+		"rank":   rank + 1,
+		"score":  int64(score),
+	})
+}
+
 // Handler: Get leaderboard
 func handleLeaderboard(w http.ResponseWriter, r *http.Request) {
 	leaderboard := getLeaderboard()
@@ -353,6 +940,61 @@ func handleLeaderboard(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(leaderboard)
 }
 
+// Handler: Historical per-day, per-country totals from daily snapshots
+func handleLeaderboardHistory(w http.ResponseWriter, r *http.Request) {
+	if redisClient == nil {
+		http.Error(w, "Redis unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	fromStr := r.URL.Query().Get("from")
+	toStr := r.URL.Query().Get("to")
+	if fromStr == "" || toStr == "" {
+		http.Error(w, "from and to (yyyymmdd) are required", http.StatusBadRequest)
+		return
+	}
+
+	from, err := time.Parse("20060102", fromStr)
+	if err != nil {
+		http.Error(w, "Invalid from date", http.StatusBadRequest)
+		return
+	}
+	to, err := time.Parse("20060102", toStr)
+	if err != nil {
+		http.Error(w, "Invalid to date", http.StatusBadRequest)
+		return
+	}
+	if to.Before(from) {
+		http.Error(w, "to must not be before from", http.StatusBadRequest)
+		return
+	}
+	if requestedDays := int(to.Sub(from).Hours()/24) + 1; requestedDays > maxHistoryDays {
+		http.Error(w, fmt.Sprintf("range spans %d days, exceeds the %d day maximum", requestedDays, maxHistoryDays), http.StatusBadRequest)
+		return
+	}
+
+	days := make([]DaySnapshot, 0)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		data, err := redisClient.HGetAll(ctx, redisKeySnapshot(d)).Result()
+		if err != nil && err != redis.Nil {
+			http.Error(w, "Failed to load history", http.StatusInternalServerError)
+			return
+		}
+
+		countries := make(map[string]int64, len(data))
+		for country, v := range data {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err == nil {
+				countries[country] = n
+			}
+		}
+		days = append(days, DaySnapshot{Date: d.Format("20060102"), Countries: countries})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(days)
+}
+
 // Handler: Health check
 func handleHealth(w http.ResponseWriter, r *http.Request) {
 	// Check Redis connection
@@ -369,6 +1011,8 @@ func handleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 func main() {
+	queueCtx, queueCancel := context.WithCancel(context.Background())
+
 	// Initialize Redis
 	if err := initRedis(); err != nil {
 		log.Printf("⚠️  Warning: Redis not available: %v", err)
@@ -379,9 +1023,16 @@ func main() {
 			log.Printf("⚠️  Warning: Failed to load from Redis: %v", err)
 		}
 
-		// Start periodic save goroutine
+		// Start the cache refresh goroutine (it also reconciles any clicks
+		// a prior HINCRBY failure left in pendingClicks) and friends.
 		stopChan := make(chan struct{})
-		go startPeriodicSave(stopChan)
+		go startCacheRefresh(stopChan)
+		go startClusterSync(stopChan)
+		go startDailySnapshot(stopChan)
+
+		if err := refreshCache(); err != nil {
+			log.Printf("⚠️  %v", err)
+		}
 
 		// Handle graceful shutdown
 		sigChan := make(chan os.Signal, 1)
@@ -391,23 +1042,39 @@ func main() {
 			<-sigChan
 			log.Println("\n🛑 Received shutdown signal...")
 			close(stopChan)
-			
-			// Final save
-			if err := saveToRedis(); err != nil {
-				log.Printf("❌ Error in final save: %v", err)
-			} else {
-				log.Println("✅ Data saved successfully")
-			}
-			
+			queueCancel()
+
+			// Final reconcile so any clicks still stuck in pendingClicks
+			// from a HINCRBY failure get one last chance to reach Redis.
+			reconcilePending()
+
 			// Close Redis connection
 			if redisClient != nil {
 				redisClient.Close()
 			}
-			
+
 			os.Exit(0)
 		}()
 	}
 
+	// Click events flow through a queue so HTTP handlers never wait on
+	// Redis directly; a pool of workers applies the increment.
+	// initRedis assigns redisClient even when its ping fails, so check
+	// reachability here instead of relying on a nil client.
+	queueClient := redisClient
+	if redisClient == nil || redisClient.Ping(ctx).Err() != nil {
+		queueClient = nil
+		if queueType != "memory" {
+			log.Printf("⚠️  Redis unavailable, falling back to memory queue despite QUEUE_TYPE=%s", queueType)
+		}
+	}
+	clickQueue = queue.New(queueType, queueClient)
+	go clickQueue.Run(queueCtx, queueWorkers, func(evt queue.Event) {
+		incrementClicks(evt.Country, evt.Clicks)
+		publishClickEvent(evt)
+	})
+	log.Printf("🧵 Click queue backend: %s (%d workers)", queueType, queueWorkers)
+
 	router := mux.NewRouter()
 
 	// API routes
@@ -415,6 +1082,10 @@ func main() {
 	api.HandleFunc("/click", handleClick).Methods("POST")
 	api.HandleFunc("/score", handleScore).Methods("POST")
 	api.HandleFunc("/leaderboard", handleLeaderboard).Methods("GET")
+	api.HandleFunc("/leaderboard/stream", handleLeaderboardStream).Methods("GET")
+	api.HandleFunc("/leaderboard/history", handleLeaderboardHistory).Methods("GET")
+	api.HandleFunc("/scores/top", handleScoreTop).Methods("GET")
+	api.HandleFunc("/scores/rank", handleScoreRank).Methods("GET")
 	api.HandleFunc("/health", handleHealth).Methods("GET")
 
 	// CORS configuration
@@ -433,7 +1104,6 @@ func main() {
 	log.Printf("🚀 Football Game Backend starting on %s", addr)
 	log.Printf("📊 Leaderboard: http://localhost%s/api/leaderboard", addr)
 	log.Printf("❤️  Health check: http://localhost%s/api/health", addr)
-	log.Printf("💾 Data saves to Redis every %v", saveInterval)
 
 	if err := http.ListenAndServe(addr, handler); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseConnStr(t *testing.T) {
+	origURL, origPassword, origDB := redisURL, redisPassword, redisDB
+	defer func() { redisURL, redisPassword, redisDB = origURL, origPassword, origDB }()
+	redisURL, redisPassword, redisDB = "localhost:6379", "", 0
+
+	tests := []struct {
+		name         string
+		raw          string
+		wantAddr     string
+		wantPassword string
+		wantDB       int
+	}{
+		{
+			name:         "empty falls back to REDIS_* env vars",
+			raw:          "",
+			wantAddr:     "localhost:6379",
+			wantPassword: "",
+			wantDB:       0,
+		},
+		{
+			name:         "full connection string",
+			raw:          "redis://:secret@redis-0:6380/3",
+			wantAddr:     "redis-0:6380",
+			wantPassword: "secret",
+			wantDB:       3,
+		},
+		{
+			name:         "no password or db falls back per-field",
+			raw:          "redis://redis-0:6380",
+			wantAddr:     "redis-0:6380",
+			wantPassword: "",
+			wantDB:       0,
+		},
+		{
+			name:         "non-numeric db path falls back to default db",
+			raw:          "redis://redis-0:6380/not-a-number",
+			wantAddr:     "redis-0:6380",
+			wantPassword: "",
+			wantDB:       0,
+		},
+		{
+			name:         "invalid URL falls back entirely",
+			raw:          "://not a url",
+			wantAddr:     "localhost:6379",
+			wantPassword: "",
+			wantDB:       0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, password, db := parseConnStr(tt.raw)
+			if addr != tt.wantAddr || password != tt.wantPassword || db != tt.wantDB {
+				t.Errorf("parseConnStr(%q) = (%q, %q, %d), want (%q, %q, %d)",
+					tt.raw, addr, password, db, tt.wantAddr, tt.wantPassword, tt.wantDB)
+			}
+		})
+	}
+}
+
+func TestClusterAddrs(t *testing.T) {
+	origConnStr, origRedisAddrs, origRedisURL := connStr, redisAddrs, redisURL
+	defer func() { connStr, redisAddrs, redisURL = origConnStr, origRedisAddrs, origRedisURL }()
+	redisURL = "localhost:6379"
+
+	tests := []struct {
+		name       string
+		connStr    string
+		redisAddrs string
+		want       []string
+	}{
+		{
+			name:       "no config falls back to single REDIS_URL",
+			connStr:    "",
+			redisAddrs: "",
+			want:       []string{"localhost:6379"},
+		},
+		{
+			name:       "CONN_STR with addrs= prefix",
+			connStr:    "addrs=redis-0:6379,redis-1:6379",
+			redisAddrs: "",
+			want:       []string{"redis-0:6379", "redis-1:6379"},
+		},
+		{
+			name:       "REDIS_ADDRS used when CONN_STR is unset",
+			connStr:    "",
+			redisAddrs: "addrs=redis-0:6379,redis-1:6379",
+			want:       []string{"redis-0:6379", "redis-1:6379"},
+		},
+		{
+			name:       "bare comma-separated host list, no prefix",
+			connStr:    "redis-0:6379, redis-1:6379",
+			redisAddrs: "",
+			want:       []string{"redis-0:6379", "redis-1:6379"},
+		},
+		{
+			name:       "CONN_STR takes priority over REDIS_ADDRS",
+			connStr:    "addrs=redis-0:6379",
+			redisAddrs: "addrs=redis-9:6379",
+			want:       []string{"redis-0:6379"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connStr, redisAddrs = tt.connStr, tt.redisAddrs
+			got := clusterAddrs()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clusterAddrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlausibleScore(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name    string
+		payload ScorePayload
+		want    bool
+	}{
+		{
+			name:    "missing startTime is rejected, not given a free pass",
+			payload: ScorePayload{Score: 1, StartTime: 0},
+			want:    false,
+		},
+		{
+			name:    "negative startTime is rejected",
+			payload: ScorePayload{Score: 1, StartTime: -5},
+			want:    false,
+		},
+		{
+			name:    "score within the per-second allowance",
+			payload: ScorePayload{Score: 50, StartTime: now - 10},
+			want:    true,
+		},
+		{
+			name:    "score too high for the elapsed time",
+			payload: ScorePayload{Score: 1000, StartTime: now - 10},
+			want:    false,
+		},
+		{
+			name:    "startTime in the future",
+			payload: ScorePayload{Score: 1, StartTime: now + 60},
+			want:    false,
+		},
+		{
+			name:    "ancient startTime rejected outright, regardless of score",
+			payload: ScorePayload{Score: 1, StartTime: now - int64(maxSessionDuration.Seconds()) - 60},
+			want:    false,
+		},
+		{
+			name:    "startTime just inside maxSessionDuration is still honored",
+			payload: ScorePayload{Score: 1, StartTime: now - int64(maxSessionDuration.Seconds()) + 5},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := plausibleScore(tt.payload); got != tt.want {
+				t.Errorf("plausibleScore(%+v) = %v, want %v", tt.payload, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// bufferedDrainInterval is how often a buffered backend retries flushing
+// events that piled up locally while the Redis-backed primary was down.
+const bufferedDrainInterval = time.Second
+
+// bufferedQueue wraps a Redis-backed primary with an in-memory fallback
+// buffer, so a Redis blip doesn't turn into a 503 for every click in
+// handleClick: Enqueue falls back to the local buffer instead of returning
+// the Redis error, and a background goroutine drains the buffer back into
+// the primary once it recovers.
+type bufferedQueue struct {
+	primary  Queue
+	fallback *memoryQueue
+}
+
+func newBufferedQueue(primary Queue) *bufferedQueue {
+	return &bufferedQueue{primary: primary, fallback: newMemoryQueue()}
+}
+
+func (q *bufferedQueue) Enqueue(ctx context.Context, evt Event) error {
+	if err := q.primary.Enqueue(ctx, evt); err == nil {
+		return nil
+	} else {
+		log.Printf("⚠️  queue: primary enqueue failed, buffering locally: %v", err)
+	}
+	return q.fallback.Enqueue(ctx, evt)
+}
+
+func (q *bufferedQueue) Run(ctx context.Context, workers int, handler func(Event)) {
+	go q.drainFallback(ctx)
+	q.primary.Run(ctx, workers, handler)
+}
+
+// drainFallback periodically tries to hand buffered events back to the
+// primary. The moment one fails it puts the event back and waits for the
+// next tick, rather than busy-retrying against a primary that's still down.
+func (q *bufferedQueue) drainFallback(ctx context.Context) {
+	ticker := time.NewTicker(bufferedDrainInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			q.flush(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (q *bufferedQueue) flush(ctx context.Context) {
+	for {
+		select {
+		case evt := <-q.fallback.events:
+			if err := q.primary.Enqueue(ctx, evt); err != nil {
+				select {
+				case q.fallback.events <- evt:
+				default:
+					log.Printf("⚠️  queue: fallback buffer full, dropping event")
+				}
+				return
+			}
+		default:
+			return
+		}
+	}
+}
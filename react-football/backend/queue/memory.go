@@ -0,0 +1,53 @@
+package queue
+
+import "context"
+
+// memoryQueueSize bounds the in-process buffered channel. Once full,
+// Enqueue blocks the caller, which is the signal to switch to a Redis
+// backend instead of silently dropping clicks.
+const memoryQueueSize = 10000
+
+// memoryQueue is the zero-dependency default: an in-memory channel. It
+// doesn't survive a restart, but it keeps handleClick fast even with no
+// Redis configured at all.
+type memoryQueue struct {
+	events chan Event
+}
+
+func newMemoryQueue() *memoryQueue {
+	return &memoryQueue{events: make(chan Event, memoryQueueSize)}
+}
+
+func (q *memoryQueue) Enqueue(ctx context.Context, evt Event) error {
+	select {
+	case q.events <- evt:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *memoryQueue) Run(ctx context.Context, workers int, handler func(Event)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	done := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case evt := <-q.events:
+					handler(evt)
+				case <-ctx.Done():
+					done <- struct{}{}
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < workers; i++ {
+		<-done
+	}
+}
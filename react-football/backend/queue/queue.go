@@ -0,0 +1,75 @@
+// Package queue decouples click ingestion from Redis latency. HTTP handlers
+// enqueue an Event and return immediately; a pool of worker goroutines
+// drains the queue and applies the increment. The backend is selected at
+// startup via QUEUE_TYPE so operators can trade off durability vs.
+// simplicity without touching the handler code.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Event is a single click ready to be applied to the click counters.
+type Event struct {
+	Country string `json:"country"`
+	Clicks  int64  `json:"clicks"`
+	IP      string `json:"ip"`
+	TS      int64  `json:"ts"`
+}
+
+// Queue is a pluggable click-event backend.
+type Queue interface {
+	// Enqueue adds an event for later processing. It must not block on
+	// anything slower than the backend's own write.
+	Enqueue(ctx context.Context, evt Event) error
+
+	// Run starts a pool of worker goroutines that drain the queue and call
+	// handler for each event. It blocks until ctx is cancelled.
+	Run(ctx context.Context, workers int, handler func(Event))
+}
+
+// New builds a Queue for the given backend type:
+//
+//	"memory"        - in-process buffered channel (default, no persistence)
+//	"redis"         - Redis LIST via LPUSH/BRPOP
+//	"redis-streams" - Redis Streams via XADD/XREADGROUP, shared via a
+//	                  consumer group so multiple replicas split the load
+//
+// Both Redis-backed types are wrapped in a bufferedQueue so they survive
+// Redis blips: a failed Enqueue falls back to an in-memory buffer that
+// drains back into Redis once it recovers, instead of failing the click.
+//
+// client is a redis.UniversalClient so the same queue backends work whether
+// main wired up a single-node client or a ClusterClient. It is ignored for
+// the memory backend so callers can build a Queue before Redis is known to
+// be reachable. If client is nil (Redis was unreachable at startup), a
+// Redis-backed queueType falls back to the memory backend instead of
+// handing the Redis backends a client that would panic on first use.
+func New(queueType string, client redis.UniversalClient) Queue {
+	if client == nil {
+		return newMemoryQueue()
+	}
+
+	switch queueType {
+	case "redis":
+		return newBufferedQueue(newRedisListQueue(client))
+	case "redis-streams":
+		return newBufferedQueue(newRedisStreamQueue(client))
+	default:
+		return newMemoryQueue()
+	}
+}
+
+func encode(evt Event) (string, error) {
+	b, err := json.Marshal(evt)
+	return string(b), err
+}
+
+func decode(payload string) (Event, error) {
+	var evt Event
+	err := json.Unmarshal([]byte(payload), &evt)
+	return evt, err
+}
@@ -0,0 +1,171 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewFallsBackToMemoryWhenClientIsNil(t *testing.T) {
+	for _, queueType := range []string{"redis", "redis-streams", "memory", "bogus"} {
+		t.Run(queueType, func(t *testing.T) {
+			q := New(queueType, nil)
+			if _, ok := q.(*memoryQueue); !ok {
+				t.Fatalf("New(%q, nil) = %T, want *memoryQueue", queueType, q)
+			}
+
+			// A nil client must never reach a Redis backend's Enqueue, or
+			// this would panic instead of returning an error.
+			if err := q.Enqueue(context.Background(), Event{Country: "Chile"}); err != nil {
+				t.Errorf("Enqueue() on memory fallback error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	want := Event{Country: "Brazil", Clicks: 3, IP: "1.2.3.4", TS: 1700000000}
+
+	payload, err := encode(want)
+	if err != nil {
+		t.Fatalf("encode() error = %v", err)
+	}
+
+	got, err := decode(payload)
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("decode(encode(evt)) = %+v, want %+v", got, want)
+	}
+}
+
+func TestMemoryQueueEnqueueAndRun(t *testing.T) {
+	q := newMemoryQueue()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var mu sync.Mutex
+	var received []Event
+	handlerDone := make(chan struct{})
+
+	go func() {
+		q.Run(ctx, 1, func(evt Event) {
+			mu.Lock()
+			received = append(received, evt)
+			mu.Unlock()
+			close(handlerDone)
+		})
+	}()
+
+	evt := Event{Country: "France", Clicks: 1}
+	if err := q.Enqueue(context.Background(), evt); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	select {
+	case <-handlerDone:
+	case <-time.After(time.Second):
+		t.Fatal("handler was never called")
+	}
+	cancel()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0] != evt {
+		t.Errorf("received = %+v, want [%+v]", received, evt)
+	}
+}
+
+func TestMemoryQueueEnqueueRespectsContextCancellation(t *testing.T) {
+	q := &memoryQueue{events: make(chan Event)} // unbuffered, so Enqueue blocks
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := q.Enqueue(ctx, Event{Country: "Spain"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Enqueue() error = %v, want context.Canceled", err)
+	}
+}
+
+// fakeQueue is a minimal in-memory Queue stand-in so bufferedQueue can be
+// tested without a real Redis server: failing can be toggled to simulate a
+// Redis blip and recovery.
+type fakeQueue struct {
+	mu      sync.Mutex
+	failing bool
+	events  []Event
+}
+
+func (q *fakeQueue) Enqueue(ctx context.Context, evt Event) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.failing {
+		return errors.New("redis unavailable")
+	}
+	q.events = append(q.events, evt)
+	return nil
+}
+
+func (q *fakeQueue) Run(ctx context.Context, workers int, handler func(Event)) {
+	<-ctx.Done()
+}
+
+func (q *fakeQueue) setFailing(failing bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.failing = failing
+}
+
+func (q *fakeQueue) snapshot() []Event {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]Event, len(q.events))
+	copy(out, q.events)
+	return out
+}
+
+func TestBufferedQueueBuffersOnPrimaryFailure(t *testing.T) {
+	primary := &fakeQueue{failing: true}
+	q := newBufferedQueue(primary)
+
+	evt := Event{Country: "Italy", Clicks: 1}
+	if err := q.Enqueue(context.Background(), evt); err != nil {
+		t.Fatalf("Enqueue() error = %v, want nil (buffered locally)", err)
+	}
+	if got := primary.snapshot(); len(got) != 0 {
+		t.Errorf("primary received %v while failing, want none", got)
+	}
+
+	select {
+	case got := <-q.fallback.events:
+		if got != evt {
+			t.Errorf("buffered event = %+v, want %+v", got, evt)
+		}
+	default:
+		t.Fatal("expected event to be buffered in fallback queue")
+	}
+}
+
+func TestBufferedQueueDrainsOnceRecovered(t *testing.T) {
+	primary := &fakeQueue{failing: true}
+	q := newBufferedQueue(primary)
+
+	evt := Event{Country: "Germany", Clicks: 2}
+	if err := q.Enqueue(context.Background(), evt); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	primary.setFailing(false)
+	q.flush(context.Background())
+
+	got := primary.snapshot()
+	if len(got) != 1 || got[0] != evt {
+		t.Errorf("primary.events after flush = %+v, want [%+v]", got, evt)
+	}
+	select {
+	case leftover := <-q.fallback.events:
+		t.Errorf("fallback still holds %+v after a successful flush", leftover)
+	default:
+	}
+}
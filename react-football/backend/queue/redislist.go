@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const redisListKey = "football:queue:clicks"
+
+// redisListQueue backs the queue with a Redis LIST. Enqueue is a cheap
+// LPUSH; workers block on BRPOP so idle workers don't poll.
+type redisListQueue struct {
+	client redis.UniversalClient
+}
+
+func newRedisListQueue(client redis.UniversalClient) *redisListQueue {
+	return &redisListQueue{client: client}
+}
+
+func (q *redisListQueue) Enqueue(ctx context.Context, evt Event) error {
+	payload, err := encode(evt)
+	if err != nil {
+		return err
+	}
+	return q.client.LPush(ctx, redisListKey, payload).Err()
+}
+
+func (q *redisListQueue) Run(ctx context.Context, workers int, handler func(Event)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	done := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				result, err := q.client.BRPop(ctx, time.Second, redisListKey).Result()
+				if err == redis.Nil {
+					continue
+				}
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					log.Printf("⚠️  queue: BRPOP failed: %v", err)
+					continue
+				}
+
+				// result is [key, value]
+				evt, err := decode(result[1])
+				if err != nil {
+					log.Printf("⚠️  queue: dropping malformed event: %v", err)
+					continue
+				}
+				handler(evt)
+			}
+		}()
+	}
+
+	for i := 0; i < workers; i++ {
+		<-done
+	}
+}
@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	redisStreamKey   = "football:stream:clicks"
+	redisStreamGroup = "football:workers"
+)
+
+// redisStreamQueue backs the queue with a Redis Stream and a consumer
+// group, so click events are load-balanced across every replica in the
+// group instead of each replica processing everything.
+type redisStreamQueue struct {
+	client   redis.UniversalClient
+	consumer string
+}
+
+func newRedisStreamQueue(client redis.UniversalClient) *redisStreamQueue {
+	consumer, err := os.Hostname()
+	if err != nil || consumer == "" {
+		consumer = fmt.Sprintf("worker-%d", time.Now().UnixNano())
+	}
+	return &redisStreamQueue{client: client, consumer: consumer}
+}
+
+func (q *redisStreamQueue) Enqueue(ctx context.Context, evt Event) error {
+	payload, err := encode(evt)
+	if err != nil {
+		return err
+	}
+	return q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: redisStreamKey,
+		Values: map[string]interface{}{"payload": payload},
+	}).Err()
+}
+
+func (q *redisStreamQueue) ensureGroup(ctx context.Context) {
+	err := q.client.XGroupCreateMkStream(ctx, redisStreamKey, redisStreamGroup, "$").Err()
+	if err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
+		log.Printf("⚠️  queue: failed to create consumer group: %v", err)
+	}
+}
+
+func (q *redisStreamQueue) Run(ctx context.Context, workers int, handler func(Event)) {
+	if workers < 1 {
+		workers = 1
+	}
+	q.ensureGroup(ctx)
+
+	done := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		consumer := fmt.Sprintf("%s-%d", q.consumer, i)
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+					Group:    redisStreamGroup,
+					Consumer: consumer,
+					Streams:  []string{redisStreamKey, ">"},
+					Count:    10,
+					Block:    time.Second,
+				}).Result()
+				if err == redis.Nil {
+					continue
+				}
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					log.Printf("⚠️  queue: XREADGROUP failed: %v", err)
+					continue
+				}
+
+				for _, stream := range streams {
+					for _, msg := range stream.Messages {
+						payload, _ := msg.Values["payload"].(string)
+						evt, err := decode(payload)
+						if err != nil {
+							log.Printf("⚠️  queue: dropping malformed event: %v", err)
+						} else {
+							handler(evt)
+						}
+						q.client.XAck(ctx, redisStreamKey, redisStreamGroup, msg.ID)
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < workers; i++ {
+		<-done
+	}
+}